@@ -3,37 +3,290 @@
 
 package world
 
+import (
+	"math/bits"
+	"sync"
+)
+
+// extension holds the mutable per-entity state that doesn't live directly
+// on Entity. safeExtension is the only implementation in this tree.
+type extension interface {
+	setType(EntityType)
+	release()
+
+	copiesAll() bool
+
+	armamentConsumption() []Ticks
+	copyArmamentConsumption()
+
+	turretAngles() []Angle
+	copyTurretAngles()
+
+	countermeasureConsumption() []Ticks
+	copyCountermeasureConsumption()
+
+	decoyActive() bool
+	setDecoyActive(bool)
+	decoyTicks() Ticks
+	setDecoyTicks(Ticks)
+
+	aimTarget() Vec2f
+	setAimTarget(Vec2f)
+
+	altitude() float32
+	setAltitude(float32)
+	altitudeTarget() float32
+	setAltitudeTarget(float32)
+
+	getSpawnProtection() Ticks
+	setSpawnProtection(Ticks)
+
+	active() bool
+	setActive(bool)
+	activeTicks() Ticks
+	setActiveTicks(Ticks)
+
+	Snapshot() ExtensionSnapshot
+	Restore(ExtensionSnapshot)
+}
+
+// extensionDefaults can be embedded by extension implementations that need
+// no despawn-time cleanup, giving them a no-op release() so adding it to
+// the interface doesn't force every implementation to write one.
+type extensionDefaults struct{}
+
+func (extensionDefaults) release() {}
+
 type safeExtension struct {
 	armaments       []Ticks // consumption of each armament
 	angles          []Angle // angle of each turret
+	countermeasures []Ticks // cooldown of each countermeasure (flares, chaff, noisemakers)
 	target          Vec2f   // aim target position
 	alt             float32 // altitude (see entity.Altitude for meaning)
 	altTarget       float32 // desired altitude
 	spawnProtection Ticks   // remaining
 	actTicks        Ticks   // remaining ticks of active (serves as rate limiter)
 	act             bool    // active sensors
+	decoy           bool    // countermeasure decoy active
+	decoyT          Ticks   // remaining ticks of decoy
 }
 
 var _ = extension(&safeExtension{})
 
+// maxPoolBucket bounds the armament/angle pools to slices up to
+// 1<<(maxPoolBucket-1) elements; anything larger falls back to a plain
+// allocation.
+const maxPoolBucket = 16
+
+// ticksPools and anglePools recycle armament/angle backing arrays, bucketed
+// by capacity rounded up to the next power of two.
+var ticksPools [maxPoolBucket]sync.Pool
+var anglePools [maxPoolBucket]sync.Pool
+
+// poolBucket returns the index of the pool holding slices of capacity
+// 1<<poolBucket(n), the smallest power of two that is >= n.
+func poolBucket(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Len(uint(n - 1))
+}
+
+func getTicks(n int) []Ticks {
+	if n == 0 {
+		return nil
+	}
+	b := poolBucket(n)
+	if b >= maxPoolBucket {
+		return make([]Ticks, n)
+	}
+	if s, ok := ticksPools[b].Get().([]Ticks); ok {
+		s = s[:n]
+		for i := range s {
+			s[i] = 0
+		}
+		return s
+	}
+	return make([]Ticks, n, 1<<b)
+}
+
+func putTicks(s []Ticks) {
+	if s == nil {
+		return
+	}
+	b := poolBucket(cap(s))
+	if b >= maxPoolBucket {
+		return
+	}
+	ticksPools[b].Put(s[:0:cap(s)])
+}
+
+func getAngles(n int) []Angle {
+	if n == 0 {
+		return nil
+	}
+	b := poolBucket(n)
+	if b >= maxPoolBucket {
+		return make([]Angle, n)
+	}
+	if s, ok := anglePools[b].Get().([]Angle); ok {
+		s = s[:n]
+		for i := range s {
+			s[i] = 0
+		}
+		return s
+	}
+	return make([]Angle, n, 1<<b)
+}
+
+func putAngles(s []Angle) {
+	if s == nil {
+		return
+	}
+	b := poolBucket(cap(s))
+	if b >= maxPoolBucket {
+		return
+	}
+	anglePools[b].Put(s[:0:cap(s)])
+}
+
 func (ext *safeExtension) setType(entityType EntityType) {
 	data := entityType.Data()
 
-	// Only keep certain fields
+	// Only keep certain fields. The old armaments/angles/countermeasures
+	// slices are NOT returned to the pool here: setType runs mid-lifetime,
+	// and the copy-on-write contract (copiesAll, copyArmamentConsumption,
+	// copyTurretAngles, copyCountermeasureConsumption) means another
+	// safeExtension may still alias them. Only release does that, since
+	// despawn is the one point at which exclusive ownership is guaranteed.
 	*ext = safeExtension{target: ext.target, altTarget: ext.altTarget, spawnProtection: ext.spawnProtection, act: ext.act}
 
 	// Replenish all
-	ext.armaments = make([]Ticks, len(data.Armaments))
+	ext.armaments = getTicks(len(data.Armaments))
+	ext.countermeasures = getTicks(len(data.Countermeasures))
 
 	// Reset turrets to base positions
 	turrets := data.Turrets
-	ext.angles = make([]Angle, len(turrets))
+	ext.angles = getAngles(len(turrets))
 
 	for i, turret := range turrets {
 		ext.angles[i] = turret.Angle
 	}
 }
 
+// release returns ext's backing arrays to their pools. It must be called
+// when the owning entity is despawned so future entities can reuse them.
+func (ext *safeExtension) release() {
+	putTicks(ext.armaments)
+	putAngles(ext.angles)
+	putTicks(ext.countermeasures)
+	ext.armaments = nil
+	ext.angles = nil
+	ext.countermeasures = nil
+}
+
+// despawnExtension releases ext's pooled backing arrays. The world's
+// entity despawn path must call this for every entity's extension.
+func despawnExtension(ext extension) {
+	ext.release()
+}
+
+// decoyDistracts reports whether tgt has an active decoy that should pull
+// homing torpedo/missile target acquisition in the world package away from
+// tgt and toward the decoy instead.
+func decoyDistracts(tgt extension) bool {
+	return tgt.decoyActive() && tgt.decoyTicks() > 0
+}
+
+// maxSnapshotArmaments, maxSnapshotAngles and maxSnapshotCountermeasures
+// bound ExtensionSnapshot's fixed-size buffers. Snapshot panics on an
+// extension with more armaments/turrets/countermeasures than this; no
+// entity data in practice comes close.
+const (
+	maxSnapshotArmaments       = 16
+	maxSnapshotAngles          = 8
+	maxSnapshotCountermeasures = 8
+)
+
+// ExtensionSnapshot is a point-in-time copy of a safeExtension's mutable
+// state. Armaments, angles and countermeasures are fixed-size arrays so a
+// whole-world snapshot is one allocation (a []ExtensionSnapshot) rather
+// than several slices per entity.
+type ExtensionSnapshot struct {
+	armaments          [maxSnapshotArmaments]Ticks
+	angles             [maxSnapshotAngles]Angle
+	countermeasures    [maxSnapshotCountermeasures]Ticks
+	numArmaments       uint8
+	numAngles          uint8
+	numCountermeasures uint8
+	target             Vec2f
+	alt                float32
+	altTarget          float32
+	spawnProtection    Ticks
+	actTicks           Ticks
+	act                bool
+	decoy              bool
+	decoyT             Ticks
+}
+
+// Snapshot captures ext's current mutable state. It panics rather than
+// truncating if ext exceeds the fixed-size buffers above, since a silent
+// truncation here would make Restore permanently drop the overflow.
+func (ext *safeExtension) Snapshot() ExtensionSnapshot {
+	if len(ext.armaments) > maxSnapshotArmaments || len(ext.angles) > maxSnapshotAngles || len(ext.countermeasures) > maxSnapshotCountermeasures {
+		panic("safeExtension: armaments/angles/countermeasures exceed ExtensionSnapshot bounds")
+	}
+
+	var snap ExtensionSnapshot
+	snap.numArmaments = uint8(copy(snap.armaments[:], ext.armaments))
+	snap.numAngles = uint8(copy(snap.angles[:], ext.angles))
+	snap.numCountermeasures = uint8(copy(snap.countermeasures[:], ext.countermeasures))
+	snap.target = ext.target
+	snap.alt = ext.alt
+	snap.altTarget = ext.altTarget
+	snap.spawnProtection = ext.spawnProtection
+	snap.actTicks = ext.actTicks
+	snap.act = ext.act
+	snap.decoy = ext.decoy
+	snap.decoyT = ext.decoyT
+	return snap
+}
+
+// Restore reapplies a previously captured snapshot to ext, reusing ext's
+// existing armament/angle/countermeasure slices when their lengths
+// already match to avoid re-allocating. Restore runs on a live, mid-
+// lifetime extension, so on a length mismatch it must NOT return ext's
+// old slice to the pool (same aliasing concern as setType: another
+// safeExtension may still share it per the copy-on-write contract).
+func (ext *safeExtension) Restore(snap ExtensionSnapshot) {
+	na := int(snap.numArmaments)
+	if len(ext.armaments) != na {
+		ext.armaments = getTicks(na)
+	}
+	copy(ext.armaments, snap.armaments[:na])
+
+	nt := int(snap.numAngles)
+	if len(ext.angles) != nt {
+		ext.angles = getAngles(nt)
+	}
+	copy(ext.angles, snap.angles[:nt])
+
+	nc := int(snap.numCountermeasures)
+	if len(ext.countermeasures) != nc {
+		ext.countermeasures = getTicks(nc)
+	}
+	copy(ext.countermeasures, snap.countermeasures[:nc])
+
+	ext.target = snap.target
+	ext.alt = snap.alt
+	ext.altTarget = snap.altTarget
+	ext.spawnProtection = snap.spawnProtection
+	ext.actTicks = snap.actTicks
+	ext.act = snap.act
+	ext.decoy = snap.decoy
+	ext.decoyT = snap.decoyT
+}
+
 func (ext *safeExtension) copiesAll() bool {
 	return false
 }
@@ -43,7 +296,7 @@ func (ext *safeExtension) armamentConsumption() []Ticks {
 }
 
 func (ext *safeExtension) copyArmamentConsumption() {
-	a := make([]Ticks, len(ext.armaments))
+	a := getTicks(len(ext.armaments))
 	copy(a, ext.armaments)
 	ext.armaments = a
 }
@@ -53,7 +306,35 @@ func (ext *safeExtension) turretAngles() []Angle {
 }
 
 func (ext *safeExtension) copyTurretAngles() {
-	ext.angles = copyAngles(ext.angles)
+	a := getAngles(len(ext.angles))
+	copy(a, ext.angles)
+	ext.angles = a
+}
+
+func (ext *safeExtension) countermeasureConsumption() []Ticks {
+	return ext.countermeasures
+}
+
+func (ext *safeExtension) copyCountermeasureConsumption() {
+	c := getTicks(len(ext.countermeasures))
+	copy(c, ext.countermeasures)
+	ext.countermeasures = c
+}
+
+func (ext *safeExtension) decoyActive() bool {
+	return ext.decoy
+}
+
+func (ext *safeExtension) setDecoyActive(val bool) {
+	ext.decoy = val
+}
+
+func (ext *safeExtension) decoyTicks() Ticks {
+	return ext.decoyT
+}
+
+func (ext *safeExtension) setDecoyTicks(val Ticks) {
+	ext.decoyT = val
 }
 
 func (ext *safeExtension) aimTarget() Vec2f {